@@ -0,0 +1,62 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSUploader uploads every file in a release directory to a GCS bucket,
+// keyed under prefix. Credentials come from the standard
+// GOOGLE_APPLICATION_CREDENTIALS/ADC chain.
+type GCSUploader struct {
+	Bucket string
+	Prefix string
+}
+
+// NewGCSUploader returns an Uploader targeting gs://bucket/prefix.
+func NewGCSUploader(bucket, prefix string) *GCSUploader {
+	return &GCSUploader{Bucket: bucket, Prefix: prefix}
+}
+
+func (u *GCSUploader) Upload(ctx context.Context, releasePath string) error {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("create gcs client: %w", err)
+	}
+	defer client.Close()
+
+	bucket := client.Bucket(u.Bucket)
+
+	return filepath.Walk(releasePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(releasePath, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(filepath.Join(u.Prefix, rel))
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w := bucket.Object(key).NewWriter(ctx)
+		if _, err := io.Copy(w, f); err != nil {
+			w.Close()
+			return fmt.Errorf("upload %s to gs://%s/%s: %w", path, u.Bucket, key, err)
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("upload %s to gs://%s/%s: %w", path, u.Bucket, key, err)
+		}
+		return nil
+	})
+}
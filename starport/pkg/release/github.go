@@ -0,0 +1,179 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const githubAPI = "https://api.github.com"
+
+// GitHubUploader creates (or reuses) a GitHub release for Tag and attaches
+// every file under the release directory as an asset. Authentication is
+// via the GITHUB_TOKEN environment variable. Release notes are generated
+// by GitHub from the commit range since the previous tag.
+type GitHubUploader struct {
+	Owner string
+	Repo  string
+	Tag   string
+}
+
+// NewGitHubUploader returns an Uploader targeting github://owner/repo@tag.
+func NewGitHubUploader(owner, repo, tag string) *GitHubUploader {
+	return &GitHubUploader{Owner: owner, Repo: repo, Tag: tag}
+}
+
+// parseGitHubDest splits a github://owner/repo@tag destination URL.
+func parseGitHubDest(u *url.URL) (owner, repo, tag string, err error) {
+	owner = u.Host
+	repo, tag, ok := strings.Cut(strings.TrimPrefix(u.Path, "/"), "@")
+	if owner == "" || repo == "" || !ok || tag == "" {
+		return "", "", "", fmt.Errorf("invalid github upload destination, want github://owner/repo@tag")
+	}
+	return owner, repo, tag, nil
+}
+
+func (u *GitHubUploader) Upload(ctx context.Context, releasePath string) error {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GITHUB_TOKEN is required to publish a github:// release")
+	}
+
+	rel, err := u.ensureRelease(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(releasePath)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if err := u.uploadAsset(ctx, token, rel.UploadHost(), filepath.Join(releasePath, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubRelease is the subset of the GitHub releases API response we need.
+type githubRelease struct {
+	ID        int64  `json:"id"`
+	UploadURL string `json:"upload_url"`
+}
+
+// UploadHost strips the {?name,label} URI template suffix GitHub appends
+// to upload_url, leaving a plain endpoint we can append "?name=" to.
+func (r githubRelease) UploadHost() string {
+	if i := strings.Index(r.UploadURL, "{"); i >= 0 {
+		return r.UploadURL[:i]
+	}
+	return r.UploadURL
+}
+
+// ensureRelease returns the existing release for u.Tag, or creates one with
+// auto-generated notes (covering the commits since the previous tag) if it
+// doesn't exist yet.
+func (u *GitHubUploader) ensureRelease(ctx context.Context, token string) (githubRelease, error) {
+	getPath := fmt.Sprintf("/repos/%s/%s/releases/tags/%s", u.Owner, u.Repo, u.Tag)
+
+	req, err := u.newRequest(ctx, token, http.MethodGet, getPath, nil)
+	if err != nil {
+		return githubRelease{}, err
+	}
+
+	var rel githubRelease
+	if err := do(req, &rel); err == nil {
+		return rel, nil
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"tag_name":               u.Tag,
+		"name":                   u.Tag,
+		"generate_release_notes": true,
+	})
+	if err != nil {
+		return githubRelease{}, err
+	}
+
+	req, err = u.newRequest(ctx, token, http.MethodPost, fmt.Sprintf("/repos/%s/%s/releases", u.Owner, u.Repo), bytes.NewReader(body))
+	if err != nil {
+		return githubRelease{}, err
+	}
+	if err := do(req, &rel); err != nil {
+		return githubRelease{}, fmt.Errorf("create github release %s: %w", u.Tag, err)
+	}
+	return rel, nil
+}
+
+func (u *GitHubUploader) uploadAsset(ctx context.Context, token, uploadHost, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	uploadURL := fmt.Sprintf("%s?name=%s", uploadHost, url.QueryEscape(filepath.Base(path)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, f)
+	if err != nil {
+		return err
+	}
+	// set ContentLength explicitly: net/http can't infer a length from an
+	// *os.File and falls back to chunked transfer-encoding, which GitHub's
+	// upload endpoint rejects.
+	req.ContentLength = info.Size()
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	if err := do(req, nil); err != nil {
+		return fmt.Errorf("upload asset %s: %w", path, err)
+	}
+	return nil
+}
+
+func (u *GitHubUploader) newRequest(ctx context.Context, token, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, githubAPI+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	return req, nil
+}
+
+// do executes req and decodes a JSON response into out (when non-nil),
+// returning an error for any non-2xx status.
+func do(req *http.Request, out any) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL, resp.Status, b)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,48 @@
+package release
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// envGPGSigningKey is the fallback key ID used when GPGBackend.KeyID is empty.
+const envGPGSigningKey = "GPG_SIGNING_KEY"
+
+// GPGBackend signs artifacts by shelling out to `gpg --detach-sign --armor`.
+// When KeyID is empty, it falls back to the GPG_SIGNING_KEY environment
+// variable and, failing that, gpg's default secret key.
+type GPGBackend struct {
+	KeyID string
+}
+
+// NewGPGBackend returns a Backend that signs with the given GPG key ID.
+func NewGPGBackend(keyID string) GPGBackend {
+	return GPGBackend{KeyID: keyID}
+}
+
+func (b GPGBackend) Sign(ctx context.Context, path string) (string, error) {
+	sigPath := path + ".asc"
+
+	keyID := b.KeyID
+	if keyID == "" {
+		keyID = os.Getenv(envGPGSigningKey)
+	}
+
+	args := []string{"--batch", "--yes", "--detach-sign", "--armor", "--output", sigPath}
+	if keyID != "" {
+		args = append(args, "--local-user", keyID)
+	}
+	args = append(args, path)
+
+	cmd := exec.CommandContext(ctx, "gpg", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "gpg sign %s", path)
+	}
+	return sigPath, nil
+}
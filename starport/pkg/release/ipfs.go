@@ -0,0 +1,94 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+const defaultIPFSAPI = "localhost:5001"
+
+// IPFSUploader adds every file in a release directory to IPFS through a
+// local (or remote) daemon's HTTP API.
+type IPFSUploader struct {
+	// APIHost is host:port of the ipfs daemon's API, e.g. "localhost:5001".
+	APIHost string
+}
+
+// NewIPFSUploader returns an Uploader that adds files via the ipfs daemon
+// API at apiHost, falling back to the default local daemon address.
+func NewIPFSUploader(apiHost string) *IPFSUploader {
+	if apiHost == "" {
+		apiHost = defaultIPFSAPI
+	}
+	return &IPFSUploader{APIHost: apiHost}
+}
+
+func (u *IPFSUploader) Upload(ctx context.Context, releasePath string) error {
+	return filepath.Walk(releasePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		cid, err := u.add(ctx, path)
+		if err != nil {
+			return fmt.Errorf("add %s to ipfs: %w", path, err)
+		}
+		fmt.Printf("%s -> ipfs://%s\n", filepath.Base(path), cid)
+		return nil
+	})
+}
+
+func (u *IPFSUploader) add(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	part, err := w.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("http://%s/api/v0/add", u.APIHost)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s: %s", resp.Status, b)
+	}
+
+	var out struct {
+		Hash string `json:"Hash"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	return out.Hash, nil
+}
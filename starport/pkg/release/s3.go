@@ -0,0 +1,61 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Uploader uploads every file in a release directory to an S3 bucket,
+// keyed under prefix. Credentials and region come from the standard AWS
+// environment/config chain.
+type S3Uploader struct {
+	Bucket string
+	Prefix string
+}
+
+// NewS3Uploader returns an Uploader targeting s3://bucket/prefix.
+func NewS3Uploader(bucket, prefix string) *S3Uploader {
+	return &S3Uploader{Bucket: bucket, Prefix: prefix}
+}
+
+func (u *S3Uploader) Upload(ctx context.Context, releasePath string) error {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("load aws config: %w", err)
+	}
+	client := s3.NewFromConfig(cfg)
+
+	return filepath.Walk(releasePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(releasePath, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(filepath.Join(u.Prefix, rel))
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(u.Bucket),
+			Key:    aws.String(key),
+			Body:   f,
+		})
+		if err != nil {
+			return fmt.Errorf("upload %s to s3://%s/%s: %w", path, u.Bucket, key, err)
+		}
+		return nil
+	})
+}
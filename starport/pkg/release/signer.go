@@ -0,0 +1,11 @@
+// Package release provides helpers for producing and attesting distributable
+// release artifacts (signing, provenance, publishing).
+package release
+
+import "context"
+
+// Backend detached-signs a release artifact and returns the path to the
+// signature sidecar file it produced (e.g. "foo.tar.gz" -> "foo.tar.gz.asc").
+type Backend interface {
+	Sign(ctx context.Context, path string) (sigPath string, err error)
+}
@@ -0,0 +1,46 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Uploader publishes a built release directory (tarballs, checksum.txt and
+// their signatures/provenance files) to a remote destination.
+type Uploader interface {
+	Upload(ctx context.Context, releasePath string) error
+}
+
+// NewUploader resolves dest's scheme to the matching Uploader:
+//
+//	s3://bucket/prefix
+//	gs://bucket/prefix
+//	github://owner/repo@tag
+//	ipfs://host:port
+func NewUploader(dest string) (Uploader, error) {
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, fmt.Errorf("parse upload destination %q: %w", dest, err)
+	}
+
+	prefix := strings.TrimPrefix(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		return NewS3Uploader(u.Host, prefix), nil
+	case "gs":
+		return NewGCSUploader(u.Host, prefix), nil
+	case "github":
+		owner, repo, tag, err := parseGitHubDest(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewGitHubUploader(owner, repo, tag), nil
+	case "ipfs":
+		return NewIPFSUploader(u.Host), nil
+	default:
+		return nil, fmt.Errorf("unsupported upload destination scheme %q", u.Scheme)
+	}
+}
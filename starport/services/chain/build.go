@@ -1,29 +1,193 @@
 package chain
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/docker/docker/pkg/archive"
+	"github.com/klauspost/pgzip"
 	"github.com/pkg/errors"
-	"github.com/tendermint/starport/starport/pkg/checksum"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/exec"
 	"github.com/tendermint/starport/starport/pkg/cmdrunner/step"
 	"github.com/tendermint/starport/starport/pkg/goanalysis"
 	"github.com/tendermint/starport/starport/pkg/gocmd"
+	"github.com/tendermint/starport/starport/pkg/release"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	releaseDir  = "release"
 	checksumTxt = "checksum.txt"
+
+	reproducibilityManifestName = "reproducibility-manifest.json"
+	envSourceDateEpoch          = "SOURCE_DATE_EPOCH"
 )
 
+// SignerBackend detached-signs a release artifact. See release.GPGBackend
+// and release.MinisignBackend for the built-in implementations.
+type SignerBackend = release.Backend
+
+// ReleaseOption configures optional behavior of BuildRelease.
+type ReleaseOption func(*releaseOptions)
+
+type releaseOptions struct {
+	signerKeyID  string
+	signer       SignerBackend
+	reproducible bool
+	dockerImage  string
+	jobs         int
+}
+
+// WithJobs caps how many targets BuildRelease builds at once. It defaults
+// to GOMAXPROCS when n <= 0 or unset, mirroring `go build`'s own default
+// parallelism.
+func WithJobs(n int) ReleaseOption {
+	return func(o *releaseOptions) {
+		o.jobs = n
+	}
+}
+
+// WithDockerImage runs each target's build inside image instead of using a
+// local toolchain, so operators without the cross-compilers a target needs
+// (e.g. mingw for windows_amd64) can still produce every release tarball.
+// image is expected to be an xgo-style image bundling Go plus the common
+// cross toolchains.
+func WithDockerImage(image string) ReleaseOption {
+	return func(o *releaseOptions) {
+		o.dockerImage = image
+	}
+}
+
+// WithReproducible makes BuildRelease produce byte-identical binaries and
+// tarballs across machines (trimmed paths, stripped symbols, normalized
+// tarball metadata, SOURCE_DATE_EPOCH pinned to the source commit). It has
+// the same effect as setting build.reproducible: true in config.yml.
+func WithReproducible(enabled bool) ReleaseOption {
+	return func(o *releaseOptions) {
+		o.reproducible = enabled
+	}
+}
+
+// reproducibleInfo carries the resolved reproducible-build settings (config
+// merged with any explicit ReleaseOption) out of preBuild to its callers.
+type reproducibleInfo struct {
+	enabled bool
+	epoch   int64
+}
+
+// targetToolchain is the CGO cross-compiler setup to build a single target
+// with, resolved by merging defaultCrossToolchains with any build.targets
+// entry for that target in config.yml.
+type targetToolchain struct {
+	CC  string
+	CXX string
+	// CGOEnabled is nil when nothing configures it explicitly, meaning
+	// CGO_ENABLED is left unset so the target inherits the ambient
+	// toolchain's own default (enabled for a native/host build). It's only
+	// forced to true/false when a cross toolchain default or build.targets
+	// entry says so.
+	CGOEnabled   *bool
+	ExtraLDFlags []string
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+// defaultCrossToolchains gives sane CC/CXX defaults for targets that
+// commonly need a cross toolchain to satisfy the CGO dependencies Cosmos
+// SDK chains pull in (e.g. libwasmvm for CosmWasm, RocksDB).
+var defaultCrossToolchains = map[string]targetToolchain{
+	"windows_amd64": {CC: "x86_64-w64-mingw32-gcc", CXX: "x86_64-w64-mingw32-g++", CGOEnabled: boolPtr(true)},
+	"linux_arm64":   {CC: "aarch64-linux-musl-gcc", CXX: "aarch64-linux-musl-g++", CGOEnabled: boolPtr(true)},
+}
+
+// resolveToolchain merges any build.targets.<goos>_<goarch> entry from
+// config.yml over defaultCrossToolchains.
+func resolveToolchain(goos, goarch string, configured map[string]TargetConfig) targetToolchain {
+	tc := defaultCrossToolchains[fmt.Sprintf("%s_%s", goos, goarch)]
+
+	if cfg, ok := configured[fmt.Sprintf("%s_%s", goos, goarch)]; ok {
+		if cfg.CC != "" {
+			tc.CC = cfg.CC
+		}
+		if cfg.CXX != "" {
+			tc.CXX = cfg.CXX
+		}
+		if cfg.CGOEnabled != nil {
+			tc.CGOEnabled = cfg.CGOEnabled
+		}
+		if len(cfg.ExtraLDFlags) > 0 {
+			tc.ExtraLDFlags = cfg.ExtraLDFlags
+		}
+	}
+
+	return tc
+}
+
+// TargetConfig is the build.targets.<goos>_<goarch> shape in config.yml,
+// letting operators pin a cross compiler per release target.
+type TargetConfig struct {
+	CC           string   `yaml:"cc"`
+	CXX          string   `yaml:"cxx"`
+	CGOEnabled   *bool    `yaml:"cgo_enabled"`
+	ExtraLDFlags []string `yaml:"extra_ldflags"`
+}
+
+// checkToolchain fails fast, before any target is built, when tc names a
+// cross compiler that isn't on PATH - rather than letting go build fail
+// midway through the release loop.
+func checkToolchain(target string, tc targetToolchain) error {
+	if tc.CC == "" {
+		return nil
+	}
+	if _, err := osexec.LookPath(tc.CC); err != nil {
+		return errors.Errorf(
+			"missing cross compiler %q for target %s: install it, or override it via build.targets.%s.cc",
+			tc.CC, target, target)
+	}
+	return nil
+}
+
+// WithSigner makes BuildRelease detach-sign every tarball and checksum.txt
+// it produces with backend, recording keyID alongside each artifact's
+// provenance file. keyID is opaque to BuildRelease and is only recorded as
+// the provenance signer_key; it is not passed to backend, which signs with
+// the key it was constructed with (e.g. release.NewGPGBackend(keyID)).
+func WithSigner(keyID string, backend SignerBackend) ReleaseOption {
+	return func(o *releaseOptions) {
+		o.signerKeyID = keyID
+		o.signer = backend
+	}
+}
+
+// provenance describes how a single release artifact was produced, so a
+// third party can verify it was built from the claimed source.
+type provenance struct {
+	GOOS      string   `json:"goos"`
+	GOARCH    string   `json:"goarch"`
+	GoVersion string   `json:"go_version"`
+	Commit    string   `json:"commit"`
+	Tag       string   `json:"tag"`
+	LDFlags   []string `json:"ldflags"`
+	SHA256    string   `json:"sha256"`
+	SignerKey string   `json:"signer_key,omitempty"`
+}
+
 // Build builds and installs app binaries.
 func (c *Chain) Build(ctx context.Context, output string) (binaryName string, err error) {
 	if err := c.setup(); err != nil {
@@ -50,7 +214,7 @@ func (c *Chain) build(ctx context.Context, output string) (err error) {
 		return err
 	}
 
-	buildFlags, err := c.preBuild(ctx)
+	buildFlags, _, _, err := c.preBuild(ctx, false)
 	if err != nil {
 		return err
 	}
@@ -71,7 +235,12 @@ func (c *Chain) build(ctx context.Context, output string) (err error) {
 // BuildRelease builds binaries for a release. targets is a list
 // of GOOS:GOARCH when provided. It defaults to your system when no targets provided.
 // prefix is used as prefix to tarballs containing each target.
-func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets ...string) (releasePath string, err error) {
+func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets []string, options ...ReleaseOption) (releasePath string, err error) {
+	var opts releaseOptions
+	for _, o := range options {
+		o(&opts)
+	}
+
 	if prefix == "" {
 		prefix = c.app.Name
 	}
@@ -84,7 +253,7 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		return "", err
 	}
 
-	buildFlags, err := c.preBuild(ctx)
+	buildFlags, ldFlags, repro, err := c.preBuild(ctx, opts.reproducible)
 	if err != nil {
 		return "", err
 	}
@@ -99,6 +268,30 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		return "", err
 	}
 
+	config, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+
+	// resolve and sanity-check every target's cross compiler up front, so a
+	// missing toolchain fails before any work is done rather than midway
+	// through the release loop.
+	toolchains := make(map[string]targetToolchain, len(targets))
+	for _, t := range targets {
+		goos, goarch, err := gocmd.ParseTarget(t)
+		if err != nil {
+			return "", err
+		}
+
+		tc := resolveToolchain(goos, goarch, config.Build.Targets)
+		if opts.dockerImage == "" {
+			if err := checkToolchain(t, tc); err != nil {
+				return "", err
+			}
+		}
+		toolchains[t] = tc
+	}
+
 	releasePath = output
 	if releasePath == "" {
 		releasePath = filepath.Join(c.app.Path, releaseDir)
@@ -112,68 +305,467 @@ func (c *Chain) BuildRelease(ctx context.Context, output, prefix string, targets
 		return "", err
 	}
 
+	jobs := opts.jobs
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	// share a single module/build cache across workers so concurrent
+	// targets don't each cold-populate their own.
+	gocache, err := goEnv(ctx, c.app.Path, "GOCACHE")
+	if err != nil {
+		return "", err
+	}
+	gomodcache, err := goEnv(ctx, c.app.Path, "GOMODCACHE")
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		checksumsMu sync.Mutex
+		checksums   = make(map[string]string, len(targets))
+
+		// logMu serializes writes from every target's prefixWriter, since
+		// they all share c.stdLog().out and a bare io.Writer isn't safe for
+		// concurrent use.
+		logMu sync.Mutex
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(jobs)
+
 	for _, t := range targets {
-		// build binary for a target, tarball it and save it under the release dir.
-		goos, goarch, err := gocmd.ParseTarget(t)
-		if err != nil {
-			return "", err
-		}
+		t := t
+		g.Go(func() error {
+			// build binary for a target, tarball it and save it under the release dir.
+			goos, goarch, err := gocmd.ParseTarget(t)
+			if err != nil {
+				return err
+			}
+			tc := toolchains[t]
+
+			out, err := os.MkdirTemp("", "")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(out)
+
+			// leave CGO_ENABLED unset (empty) unless a cross toolchain
+			// default or build.targets entry explicitly says so, so go
+			// build falls back to its own default: enabled for a native
+			// build, disabled for a cross build with no CC. Forcing it to
+			// "1" unconditionally broke pure-Go cross builds (darwin/*,
+			// windows_arm64, linux_386, ...) that have no cross compiler
+			// configured; forcing it to "0" unconditionally broke native
+			// builds of chains with cgo dependencies (libwasmvm, RocksDB).
+			cgoEnabled := ""
+			if tc.CGOEnabled != nil {
+				cgoEnabled = "0"
+				if *tc.CGOEnabled {
+					cgoEnabled = "1"
+				}
+			}
+
+			buildOptions := []exec.Option{
+				exec.StepOption(step.Env(
+					cmdrunner.Env(gocmd.EnvGOOS, goos),
+					cmdrunner.Env(gocmd.EnvGOARCH, goarch),
+					cmdrunner.Env("CGO_ENABLED", cgoEnabled),
+					cmdrunner.Env("CC", tc.CC),
+					cmdrunner.Env("CXX", tc.CXX),
+					cmdrunner.Env("CGO_LDFLAGS", strings.Join(tc.ExtraLDFlags, " ")),
+					cmdrunner.Env("GOCACHE", gocache),
+					cmdrunner.Env("GOMODCACHE", gomodcache),
+				)),
+				exec.StepOption(step.Stdout(newPrefixWriter(c.stdLog().out, &logMu, t))),
+				exec.StepOption(step.Stderr(newPrefixWriter(c.stdLog().out, &logMu, t))),
+			}
+
+			if opts.dockerImage != "" {
+				if err := dockerBuildPath(gctx, opts.dockerImage, c.app.Path, out, binary, mainPath, goos, goarch, cgoEnabled, tc, buildFlags); err != nil {
+					return err
+				}
+			} else if err := gocmd.BuildPath(gctx, out, binary, mainPath, buildFlags, buildOptions...); err != nil {
+				return err
+			}
+
+			if goos == "linux" {
+				if err := c.packageLinuxTarget(releasePath, prefix, goarch, binary, filepath.Join(out, binary)); err != nil {
+					return err
+				}
+			}
+
+			var tarr io.ReadCloser
+			if repro.enabled {
+				tarr, err = reproducibleTar(out, repro.epoch)
+			} else {
+				// a plain (non-reproducible) tarball is gzipped in parallel,
+				// since Cosmos binaries routinely run 80-150MB and a
+				// single-threaded gzip would dominate build time.
+				tarr, err = pgzipTar(out)
+			}
+			if err != nil {
+				return err
+			}
+
+			tarName := fmt.Sprintf("%s_%s_%s.tar.gz", prefix, goos, goarch)
+			tarPath := filepath.Join(releasePath, tarName)
+
+			tarf, err := os.Create(tarPath)
+			if err != nil {
+				return err
+			}
+			defer tarf.Close()
+
+			hasher := sha256.New()
+			if _, err := io.Copy(io.MultiWriter(tarf, hasher), tarr); err != nil {
+				return err
+			}
+			tarf.Close()
+			sum := hex.EncodeToString(hasher.Sum(nil))
+
+			checksumsMu.Lock()
+			checksums[tarName] = sum
+			checksumsMu.Unlock()
+
+			if opts.signer != nil {
+				if _, err := opts.signer.Sign(gctx, tarPath); err != nil {
+					return errors.Wrapf(err, "sign %s", tarPath)
+				}
+			}
+
+			return writeProvenance(tarPath, provenance{
+				GOOS:      goos,
+				GOARCH:    goarch,
+				GoVersion: runtime.Version(),
+				Commit:    c.sourceVersion.hash,
+				Tag:       c.sourceVersion.tag,
+				LDFlags:   ldFlags,
+				SHA256:    sum,
+				SignerKey: opts.signerKeyID,
+			})
+		})
+	}
 
-		out, err := os.MkdirTemp("", "")
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+
+	// every tarball's sha256 was already computed while it was written;
+	// hash the remaining release artifacts (nfpm packages, detached
+	// signatures, provenance manifests) that weren't, so checksum.txt
+	// covers every file written so far rather than just the tarballs.
+	// checksum.txt's own signature and the reproducibility manifest are
+	// written after it and are not listed in it.
+	entries, err := os.ReadDir(releasePath)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if _, ok := checksums[e.Name()]; ok {
+			continue
+		}
+		sum, err := sha256File(filepath.Join(releasePath, e.Name()))
 		if err != nil {
 			return "", err
 		}
-		defer os.RemoveAll(out)
+		checksums[e.Name()] = sum
+	}
 
-		buildOptions := []exec.Option{
-			exec.StepOption(step.Env(
-				cmdrunner.Env(gocmd.EnvGOOS, goos),
-				cmdrunner.Env(gocmd.EnvGOARCH, goarch),
-			)),
-		}
+	checksumPath := filepath.Join(releasePath, checksumTxt)
 
-		if err := gocmd.BuildPath(ctx, out, binary, mainPath, buildFlags, buildOptions...); err != nil {
-			return "", err
+	if err := writeChecksums(checksumPath, checksums); err != nil {
+		return "", err
+	}
+
+	if opts.signer != nil {
+		if _, err := opts.signer.Sign(ctx, checksumPath); err != nil {
+			return "", errors.Wrapf(err, "sign %s", checksumPath)
 		}
+	}
 
-		tarr, err := archive.Tar(out, archive.Gzip)
-		if err != nil {
+	if repro.enabled {
+		if err := writeReproducibilityManifest(releasePath, c.app.Path, targets); err != nil {
 			return "", err
 		}
+	}
 
-		tarName := fmt.Sprintf("%s_%s_%s.tar.gz", prefix, goos, goarch)
-		tarPath := filepath.Join(releasePath, tarName)
+	// return with the path to release dir.
+	return releasePath, nil
+}
 
-		tarf, err := os.Create(tarPath)
-		if err != nil {
-			return "", err
+// PublishRelease uploads every file under releasePath (as produced by
+// BuildRelease) to dest. dest's scheme selects the backend, e.g.
+// "s3://bucket/prefix", "gs://bucket/prefix", "github://owner/repo@tag" or
+// "ipfs://host:port". See release.NewUploader for the full set.
+func (c *Chain) PublishRelease(ctx context.Context, releasePath, dest string) error {
+	uploader, err := release.NewUploader(dest)
+	if err != nil {
+		return err
+	}
+	return uploader.Upload(ctx, releasePath)
+}
+
+// tarGzDir streams dir as a gzip-compressed tar, using newGzip to construct
+// the gzip writer and normalize (when non-nil) to rewrite each file's
+// header before it's written - e.g. to zero mtime/uid/gid for reproducible
+// builds. It underlies both reproducibleTar and pgzipTar.
+func tarGzDir(dir string, newGzip func(io.Writer) io.WriteCloser, normalize func(*tar.Header)) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		gz := newGzip(pw)
+		tw := tar.NewWriter(gz)
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			if rel == "." {
+				return nil
+			}
+
+			hdr, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			hdr.Name = rel
+			if normalize != nil {
+				normalize(hdr)
+			}
+
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		if err == nil {
+			err = gz.Close()
 		}
-		defer tarf.Close()
+		pw.CloseWithError(err)
+	}()
 
-		if _, err := io.Copy(tarf, tarr); err != nil {
-			return "", err
+	return pr
+}
+
+// reproducibleTar behaves like archive.Tar(dir, archive.Gzip), except every
+// file's mtime/uid/gid is normalized to epoch/root so the resulting tarball
+// is byte-identical regardless of which machine produced it.
+func reproducibleTar(dir string, epoch int64) (io.ReadCloser, error) {
+	modTime := time.Unix(epoch, 0).UTC()
+
+	return tarGzDir(dir, func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	}, func(hdr *tar.Header) {
+		hdr.ModTime, hdr.AccessTime, hdr.ChangeTime = modTime, modTime, modTime
+		hdr.Uid, hdr.Gid = 0, 0
+		hdr.Uname, hdr.Gname = "", ""
+	}), nil
+}
+
+// pgzipTar behaves like archive.Tar(dir, archive.Gzip), but gzips with a
+// parallel compressor since Cosmos binaries routinely run 80-150MB and a
+// single-threaded gzip would dominate build time.
+func pgzipTar(dir string) (io.ReadCloser, error) {
+	return tarGzDir(dir, func(w io.Writer) io.WriteCloser {
+		return pgzip.NewWriter(w)
+	}, nil), nil
+}
+
+// goEnv returns the value of `go env key`, resolved from dir's module.
+func goEnv(ctx context.Context, dir, key string) (string, error) {
+	cmd := osexec.CommandContext(ctx, "go", "env", key)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "go env %s", key)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// writeChecksums writes checksum.txt from precomputed sha256 sums, keyed by
+// file name, in a stable order.
+func writeChecksums(path string, sums map[string]string) error {
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", sums[name], name)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// prefixWriter prepends "[prefix] " to every line written to w, so parallel
+// per-target build output can be told apart in the combined log. mu must be
+// shared by every prefixWriter wrapping the same underlying w, since w
+// itself isn't documented as safe for concurrent writes and each line is
+// written in two calls that must not interleave with another target's.
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix []byte
+}
+
+func newPrefixWriter(w io.Writer, mu *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{w: w, mu: mu, prefix: []byte("[" + prefix + "] ")}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if _, err := p.w.Write(p.prefix); err != nil {
+			return 0, err
+		}
+		if _, err := p.w.Write(line); err != nil {
+			return 0, err
 		}
-		tarf.Close()
 	}
+	return len(b), nil
+}
 
-	checksumPath := filepath.Join(releasePath, checksumTxt)
+// reproducibilityManifest lists every input that determined the contents of
+// a reproducible release, so a third party can rebuild and diff it.
+type reproducibilityManifest struct {
+	GoVersion   string   `json:"go_version"`
+	GoSumSHA256 string   `json:"go_sum_sha256,omitempty"`
+	Targets     []string `json:"targets"`
+}
 
-	// create a checksum.txt and return with the path to release dir.
-	return releasePath, checksum.Sum(releasePath, checksumPath)
+func writeReproducibilityManifest(releasePath, appPath string, targets []string) error {
+	manifest := reproducibilityManifest{
+		GoVersion: runtime.Version(),
+		Targets:   targets,
+	}
+
+	if sum, err := sha256File(filepath.Join(appPath, "go.sum")); err == nil {
+		manifest.GoSumSHA256 = sum
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(releasePath, reproducibilityManifestName), data, 0644)
+}
+
+// dockerBuildPath runs a target's build inside image instead of relying on
+// a local cross toolchain, mounting appPath read-only at /src (the build
+// only ever writes to /out) and collecting the resulting binary into
+// outDir. image is expected to bundle Go plus the common cross toolchains,
+// xgo-style; the resolved CC/CXX/CGO_ENABLED/CGO_LDFLAGS are passed through
+// so the image's cross compilers actually get used for cgo targets.
+func dockerBuildPath(ctx context.Context, image, appPath, outDir, binary, mainPath string, goos, goarch, cgoEnabled string, tc targetToolchain, buildFlags []string) error {
+	relMain, err := filepath.Rel(appPath, mainPath)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"run", "--rm",
+		"-v", fmt.Sprintf("%s:/src:ro", appPath),
+		"-v", fmt.Sprintf("%s:/out", outDir),
+		"-w", "/src",
+		"-e", fmt.Sprintf("GOOS=%s", goos),
+		"-e", fmt.Sprintf("GOARCH=%s", goarch),
+		"-e", fmt.Sprintf("CGO_ENABLED=%s", cgoEnabled),
+		"-e", fmt.Sprintf("CC=%s", tc.CC),
+		"-e", fmt.Sprintf("CXX=%s", tc.CXX),
+		"-e", fmt.Sprintf("CGO_LDFLAGS=%s", strings.Join(tc.ExtraLDFlags, " ")),
+		image,
+		"go", "build",
+	}
+	args = append(args, buildFlags...)
+	args = append(args, "-o", filepath.Join("/out", binary), "./"+relMain)
+
+	cmd := osexec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "docker build %s/%s in %s", goos, goarch, image)
+	}
+	return nil
+}
+
+// sha256File returns the hex-encoded sha256 sum of the file at path.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeProvenance writes a provenance JSON file next to tarPath, so third
+// parties can verify how the artifact at tarPath was produced.
+func writeProvenance(tarPath string, p provenance) error {
+	provPath := strings.TrimSuffix(tarPath, ".tar.gz") + ".provenance.json"
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(provPath, data, 0644)
 }
 
-func (c *Chain) preBuild(ctx context.Context) (buildFlags []string, err error) {
+// preBuild prepares the module and computes the ldflags/build flags to
+// build with. ldFlags is returned separately from buildFlags (which embeds
+// it, already joined, behind "-ldflags") so callers that need to record the
+// actual ldflags content, e.g. for provenance, don't have to parse it back
+// out. When reproducible is true (or config.yml sets build.reproducible),
+// preBuild also pins the toolchain, trims and strips the binary, and
+// resolves the SOURCE_DATE_EPOCH the release tarball should be normalized
+// to.
+func (c *Chain) preBuild(ctx context.Context, reproducible bool) (buildFlags, ldFlags []string, repro reproducibleInfo, err error) {
 	config, err := c.Config()
 	if err != nil {
-		return nil, err
+		return nil, nil, repro, err
 	}
+	repro.enabled = reproducible || config.Build.Reproducible
 
 	chainID, err := c.ID()
 	if err != nil {
-		return nil, err
+		return nil, nil, repro, err
 	}
 
-	ldFlags := config.Build.LDFlags
+	ldFlags = config.Build.LDFlags
 	ldFlags = append(ldFlags,
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Name=%s", strings.Title(c.app.Name)),
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.AppName=%sd", c.app.Name),
@@ -183,21 +775,66 @@ func (c *Chain) preBuild(ctx context.Context) (buildFlags []string, err error) {
 	)
 	buildFlags = []string{
 		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
-		gocmd.FlagLdflags, gocmd.Ldflags(ldFlags...),
 	}
 
+	if repro.enabled {
+		if err := pinToolchain(config.Build.GoVersion); err != nil {
+			return nil, nil, repro, err
+		}
+
+		repro.epoch, err = sourceDateEpoch(ctx, c.app.Path, c.sourceVersion.hash)
+		if err != nil {
+			return nil, nil, repro, err
+		}
+		if err := os.Setenv(envSourceDateEpoch, strconv.FormatInt(repro.epoch, 10)); err != nil {
+			return nil, nil, repro, err
+		}
+
+		ldFlags = append(ldFlags, "-s", "-w", "-buildid=")
+		buildFlags = append(buildFlags, gocmd.FlagTrimPath)
+	}
+
+	buildFlags = append(buildFlags, gocmd.FlagLdflags, gocmd.Ldflags(ldFlags...))
+
 	fmt.Fprintln(c.stdLog().out, "📦 Installing dependencies...")
 
 	if err := gocmd.ModTidy(ctx, c.app.Path); err != nil {
-		return nil, err
+		return nil, nil, repro, err
 	}
 	if err := gocmd.ModVerify(ctx, c.app.Path); err != nil {
-		return nil, err
+		return nil, nil, repro, err
 	}
 
 	fmt.Fprintln(c.stdLog().out, "🛠️  Building the blockchain...")
 
-	return buildFlags, nil
+	return buildFlags, ldFlags, repro, nil
+}
+
+// pinToolchain fails fast when the running Go toolchain doesn't match want,
+// so a reproducible build never silently compiles with the wrong compiler.
+func pinToolchain(want string) error {
+	if want == "" {
+		return nil
+	}
+	if got := runtime.Version(); got != want {
+		return errors.Errorf(
+			"reproducible build requires Go %s, but the running toolchain is %s; "+
+				"install it or adjust build.go_version in config.yml", want, got)
+	}
+	return nil
+}
+
+// sourceDateEpoch resolves the unix commit time of hash in the repository
+// at path, for use as SOURCE_DATE_EPOCH.
+func sourceDateEpoch(ctx context.Context, path, hash string) (int64, error) {
+	cmd := osexec.CommandContext(ctx, "git", "show", "-s", "--format=%ct", hash)
+	cmd.Dir = path
+
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, errors.Wrap(err, "resolve commit time for SOURCE_DATE_EPOCH")
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
 }
 
 func (c *Chain) discoverMain(path string) (pkgPath string, err error) {
@@ -0,0 +1,138 @@
+package chain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goreleaser/nfpm/v2"
+	_ "github.com/goreleaser/nfpm/v2/apk"
+	_ "github.com/goreleaser/nfpm/v2/arch"
+	_ "github.com/goreleaser/nfpm/v2/deb"
+	"github.com/goreleaser/nfpm/v2/files"
+	_ "github.com/goreleaser/nfpm/v2/rpm"
+)
+
+// nfpmArchNames maps our goarch names to the arch names distro packaging
+// tools expect.
+var nfpmArchNames = map[string]string{
+	"amd64":    "amd64",
+	"arm64":    "arm64",
+	"386":      "386",
+	"arm":      "arm7",
+	"mips64le": "mips64le",
+	"ppc64le":  "ppc64le",
+	"riscv64":  "riscv64",
+	"s390x":    "s390x",
+}
+
+// packageLinuxTarget wraps the already-built binary at binPath into every
+// format listed in build.packages (deb, rpm, apk, archlinux), bundling a
+// systemd unit, a default app.toml and a postinstall script that provisions
+// a dedicated service user and a /var/lib data directory.
+func (c *Chain) packageLinuxTarget(releasePath, prefix, goarch, binary, binPath string) error {
+	config, err := c.Config()
+	if err != nil {
+		return err
+	}
+	if len(config.Build.Packages) == 0 {
+		return nil
+	}
+
+	nfpmArch, ok := nfpmArchNames[goarch]
+	if !ok {
+		return fmt.Errorf("unsupported goarch %q for packaging into %v", goarch, config.Build.Packages)
+	}
+
+	workDir, err := os.MkdirTemp("", "nfpm")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	unitPath := filepath.Join(workDir, binary+".service")
+	if err := os.WriteFile(unitPath, []byte(systemdUnit(c.app.Name, binary)), 0644); err != nil {
+		return err
+	}
+
+	appTOMLPath := filepath.Join(workDir, "app.toml")
+	if err := os.WriteFile(appTOMLPath, []byte(defaultAppTOML), 0644); err != nil {
+		return err
+	}
+
+	postInstallPath := filepath.Join(workDir, "postinstall.sh")
+	if err := os.WriteFile(postInstallPath, []byte(postInstallScript(binary)), 0755); err != nil {
+		return err
+	}
+
+	info := &nfpm.Info{
+		Name:        binary,
+		Arch:        nfpmArch,
+		Platform:    "linux",
+		Version:     c.sourceVersion.tag,
+		Maintainer:  config.Build.PackageMaintainer,
+		Description: config.Build.PackageDescription,
+		Homepage:    config.Build.PackageHomepage,
+		License:     config.Build.PackageLicense,
+		Overridables: nfpm.Overridables{
+			Contents: files.Contents{
+				&files.Content{Source: binPath, Destination: "/usr/bin/" + binary},
+				&files.Content{Source: unitPath, Destination: "/etc/systemd/system/" + binary + ".service"},
+				&files.Content{Source: appTOMLPath, Destination: "/etc/" + binary + "/app.toml", Type: "config"},
+			},
+			Scripts: nfpm.Scripts{
+				PostInstall: postInstallPath,
+			},
+		},
+	}
+
+	for _, format := range config.Build.Packages {
+		packager, err := nfpm.Get(format)
+		if err != nil {
+			return fmt.Errorf("unsupported package format %q: %w", format, err)
+		}
+
+		info.Target = fmt.Sprintf("%s_%s_%s.%s", prefix, goarch, format, packager.ConventionalExtension())
+
+		f, err := os.Create(filepath.Join(releasePath, info.Target))
+		if err != nil {
+			return err
+		}
+
+		err = packager.Package(nfpm.WithDefaults(info), f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("package %s as %s: %w", binary, format, err)
+		}
+	}
+
+	return nil
+}
+
+func systemdUnit(appName, binary string) string {
+	return fmt.Sprintf(`[Unit]
+Description=%s node
+After=network-online.target
+
+[Service]
+User=%s
+ExecStart=/usr/bin/%s start
+Restart=on-failure
+
+[Install]
+WantedBy=multi-user.target
+`, appName, binary, binary)
+}
+
+func postInstallScript(binary string) string {
+	return fmt.Sprintf(`#!/bin/sh
+set -e
+id -u %s >/dev/null 2>&1 || useradd --system --home /var/lib/%s --shell /usr/sbin/nologin %s
+mkdir -p /var/lib/%s
+chown -R %s:%s /var/lib/%s
+systemctl daemon-reload || true
+`, binary, binary, binary, binary, binary, binary, binary)
+}
+
+const defaultAppTOML = `minimum-gas-prices = ""
+`